@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewOutputEncoder 构造一个直接产出 outputLog 字段格式（Path/Level/Content/TraceId/CreatedAt）
+// 的 zapcore.Encoder，取代旧版 Conf.Write 里“JSON 编码 -> Unmarshal -> 重新 Marshal”的开销。
+//
+// 典型用法：搭配 Conf 作为 WriteSyncer 自行组装 core，例如：
+//
+//	zapcore.NewCore(logger.NewOutputEncoder(), zapcore.AddSync(conf), level)
+func NewOutputEncoder() zapcore.Encoder {
+	cfg := zapcore.EncoderConfig{
+		MessageKey: "Content",
+		LevelKey:   "Level",
+		TimeKey:    "CreatedAt",
+		CallerKey:  "Path",
+		EncodeLevel: func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt(outputLevelToInt(l))
+		},
+		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(t.Format(time.DateTime))
+		},
+		EncodeCaller: zapcore.ShortCallerEncoder,
+	}
+	return &outputEncoder{Encoder: zapcore.NewJSONEncoder(cfg)}
+}
+
+// outputEncoder 在标准 JSON encoder 基础上，把 trace_id 字段重命名为 TraceId，
+// 与 outputLog 的字段名保持一致（兼容既有下游解析）。
+type outputEncoder struct {
+	zapcore.Encoder
+}
+
+func (e *outputEncoder) Clone() zapcore.Encoder {
+	return &outputEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *outputEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	renamed := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		if f.Key == "trace_id" {
+			f.Key = "TraceId"
+		}
+		renamed[i] = f
+	}
+	return e.Encoder.EncodeEntry(entry, renamed)
+}
+
+// AddString 让通过 Logger.With(...) 挂载的 trace_id 同样以 TraceId 写出。
+func (e *outputEncoder) AddString(key, value string) {
+	if key == "trace_id" {
+		key = "TraceId"
+	}
+	e.Encoder.AddString(key, value)
+}
+
+// outputLevelToInt 把 zapcore.Level 映射为整型等级，与 internal 包的映射保持一致，
+// 用于保持历史兼容：外部系统可能依赖 Level 为数字而非字符串。
+func outputLevelToInt(level zapcore.Level) int {
+	switch level {
+	case zapcore.InfoLevel:
+		return 1
+	case zapcore.WarnLevel:
+		return 3
+	case zapcore.ErrorLevel:
+		return 4
+	case zapcore.PanicLevel:
+		return 5
+	case zapcore.DebugLevel:
+		return 6
+	default:
+		return 0
+	}
+}
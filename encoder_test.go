@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewOutputEncoderProducesOutputLogFields(t *testing.T) {
+	conf := &Conf{}
+	handleCalled := 0
+	var last []byte
+	conf.WithHandle(func(b []byte) {
+		handleCalled++
+		last = append(last[:0], b...)
+	})
+
+	core := zapcore.NewCore(NewOutputEncoder(), zapcore.AddSync(conf), zapcore.InfoLevel)
+	logger := zap.New(core, zap.AddCaller())
+	logger.Info("测试", zap.String("trace_id", "trace-1"))
+
+	if handleCalled == 0 {
+		t.Fatalf("expected Conf.Write to be invoked via the encoder's WriteSyncer")
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(last, &m); err != nil {
+		t.Fatalf("expected json output, got error: %v", err)
+	}
+	if m["Content"] != "测试" {
+		t.Fatalf("expected Content to be 测试, got: %v", m["Content"])
+	}
+	if _, ok := m["Level"]; !ok {
+		t.Fatalf("expected numeric Level field, got: %v", m)
+	}
+	if _, ok := m["Path"]; !ok {
+		t.Fatalf("expected Path field from caller info, got: %v", m)
+	}
+	if _, ok := m["CreatedAt"]; !ok {
+		t.Fatalf("expected CreatedAt field, got: %v", m)
+	}
+	// outputEncoder 的核心职责：把 trace_id 重命名为 TraceId。
+	if m["TraceId"] != "trace-1" {
+		t.Fatalf("expected trace_id field renamed to TraceId, got: %v", m)
+	}
+	if _, ok := m["trace_id"]; ok {
+		t.Fatalf("expected original trace_id key to be removed, got: %v", m)
+	}
+}
+
+func TestOutputEncoderCloneIsIndependent(t *testing.T) {
+	enc := NewOutputEncoder()
+	cloned := enc.Clone()
+	if cloned == enc {
+		t.Fatalf("expected Clone to return a distinct encoder instance")
+	}
+}
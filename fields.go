@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ctxKey 是 context.Context 中存放调用链字段的 key 类型，避免与其他包的 key 冲突。
+type ctxKey int
+
+const (
+	ctxKeyTraceId ctxKey = iota
+	ctxKeySpanId
+	ctxKeyUserId
+	ctxKeyTenantId
+)
+
+// ContextWithTraceId 把 trace_id 写入 ctx，供 ContextFields 取出。
+func ContextWithTraceId(ctx context.Context, traceId string) context.Context {
+	return context.WithValue(ctx, ctxKeyTraceId, traceId)
+}
+
+// ContextWithSpanId 把 span_id 写入 ctx，供 ContextFields 取出。
+func ContextWithSpanId(ctx context.Context, spanId string) context.Context {
+	return context.WithValue(ctx, ctxKeySpanId, spanId)
+}
+
+// ContextWithUserId 把 user_id 写入 ctx，供 ContextFields 取出。
+func ContextWithUserId(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, ctxKeyUserId, userId)
+}
+
+// ContextWithTenantId 把 tenant_id 写入 ctx，供 ContextFields 取出。
+func ContextWithTenantId(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, ctxKeyTenantId, tenantId)
+}
+
+// ContextFields 从 ctx 中取出 trace_id/span_id/user_id/tenant_id（由上面的 ContextWith* 写入），
+// 组装成 zap 字段，便于一次性挂载：logger.With(logger.ContextFields(ctx)...)。
+// 不存在的字段会被跳过，ctx 为 nil 或四个字段都不存在时返回空切片。
+func ContextFields(ctx context.Context) []zapcore.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields := make([]zapcore.Field, 0, 4)
+	if v, ok := ctx.Value(ctxKeyTraceId).(string); ok && v != "" {
+		fields = append(fields, zap.String("trace_id", v))
+	}
+	if v, ok := ctx.Value(ctxKeySpanId).(string); ok && v != "" {
+		fields = append(fields, zap.String("span_id", v))
+	}
+	if v, ok := ctx.Value(ctxKeyUserId).(string); ok && v != "" {
+		fields = append(fields, zap.String("user_id", v))
+	}
+	if v, ok := ctx.Value(ctxKeyTenantId).(string); ok && v != "" {
+		fields = append(fields, zap.String("tenant_id", v))
+	}
+	return fields
+}
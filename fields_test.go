@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextFieldsCollectsOnlySetValues(t *testing.T) {
+	if fields := ContextFields(nil); fields != nil {
+		t.Fatalf("expected nil ctx to yield nil fields, got: %v", fields)
+	}
+
+	ctx := context.Background()
+	if fields := ContextFields(ctx); len(fields) != 0 {
+		t.Fatalf("expected empty ctx to yield no fields, got: %v", fields)
+	}
+
+	ctx = ContextWithTraceId(ctx, "trace-1")
+	ctx = ContextWithUserId(ctx, "user-1")
+
+	fields := ContextFields(ctx)
+	got := map[string]string{}
+	for _, f := range fields {
+		got[f.Key] = f.String
+	}
+	if got["trace_id"] != "trace-1" {
+		t.Fatalf("expected trace_id field trace-1, got: %v", got)
+	}
+	if got["user_id"] != "user-1" {
+		t.Fatalf("expected user_id field user-1, got: %v", got)
+	}
+	if _, ok := got["span_id"]; ok {
+		t.Fatalf("expected span_id to be absent when never set, got: %v", got)
+	}
+	if _, ok := got["tenant_id"]; ok {
+		t.Fatalf("expected tenant_id to be absent when never set, got: %v", got)
+	}
+}
+
+func TestContextFieldsCollectsAllFourWhenSet(t *testing.T) {
+	ctx := context.Background()
+	ctx = ContextWithTraceId(ctx, "trace-1")
+	ctx = ContextWithSpanId(ctx, "span-1")
+	ctx = ContextWithUserId(ctx, "user-1")
+	ctx = ContextWithTenantId(ctx, "tenant-1")
+
+	fields := ContextFields(ctx)
+	if len(fields) != 4 {
+		t.Fatalf("expected all 4 fields when all are set, got: %v", fields)
+	}
+}
@@ -0,0 +1,143 @@
+// Package ginlog 提供基于 gin 的请求日志与 panic 恢复中间件。
+//
+// Logger 中间件为请求生成/透传 trace_id，并把携带 trace_id 的 *zap.Logger 存入
+// request.Context()，使业务代码可以通过 WithContext 拿到同一个 logger；trace_id
+// 同时以 logger.ContextWithTraceId 写入 context，供 logger.ContextFields 复用。
+// Recovery 中间件不生成 trace_id，只通过 WithContext 复用 Logger 挂载的 logger。
+// 日志最终经由 remoteCore 的 trace_id 提取路径串联起整条调用链。
+package ginlog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fireflycore/go-logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// loggerKey 是 context.Context 中存放 *zap.Logger 的 key 类型，避免与其他包的 key 冲突。
+type loggerKey struct{}
+
+const (
+	// HeaderRequestId 是入站请求中用于透传 trace_id 的 header。
+	HeaderRequestId = "X-Request-Id"
+	// HeaderTraceParent 是 W3C Trace Context 规范的 header，格式为 version-traceid-parentid-flags。
+	HeaderTraceParent = "traceparent"
+	// FieldTraceId 是注入 zap 字段以及 gin.Context 的 key，与 internal.remoteCore 提取的字段名一致。
+	FieldTraceId = "trace_id"
+)
+
+// Logger 返回一个 gin 中间件：记录每个请求的关键字段，并为请求生成/透传 trace_id。
+//
+// trace_id 优先取自 X-Request-Id，其次解析 traceparent，都没有则随机生成一个。
+// 生成的 trace_id 会写回响应头，并通过 zap.Logger.With 挂载到 logger 上，
+// 存入 request.Context()，供 WithContext 以及后续 handler 使用。
+func Logger(l *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		traceId := extractTraceId(c.Request)
+
+		reqLogger := l.With(zap.String(FieldTraceId, traceId))
+		ctx := logger.ContextWithTraceId(c.Request.Context(), traceId)
+		ctx = context.WithValue(ctx, loggerKey{}, reqLogger)
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(FieldTraceId, traceId)
+		c.Writer.Header().Set(HeaderRequestId, traceId)
+
+		c.Next()
+
+		latency := time.Since(start)
+		fields := []zap.Field{
+			zap.Int("status", c.Writer.Status()),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("query", c.Request.URL.RawQuery),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Duration("latency", latency),
+			zap.Int64("request_size", c.Request.ContentLength),
+			zap.Int("response_size", c.Writer.Size()),
+		}
+
+		if len(c.Errors) > 0 {
+			reqLogger.Error(c.Errors.String(), fields...)
+			return
+		}
+		reqLogger.Info("http request", fields...)
+	}
+}
+
+// Recovery 返回一个 gin 中间件：捕获 handler 中的 panic，记录 Error 日志后返回 500。
+//
+// stack 为 true 时会附带调用栈，便于定位问题；生产环境下建议按需开启，避免日志体积过大。
+func Recovery(l *zap.Logger, stack bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			reqLogger := WithContext(c.Request.Context())
+			if reqLogger == zap.L() {
+				// 未经过 Logger 中间件（context 中没有挂载 logger）时，回退到调用方传入的 l。
+				reqLogger = l
+			}
+
+			fields := []zap.Field{
+				zap.Any("error", r),
+				zap.String("path", c.Request.URL.Path),
+			}
+			if stack {
+				fields = append(fields, zap.StackSkip("stack", 3))
+			}
+			reqLogger.Error("http handler panic", fields...)
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}()
+		c.Next()
+	}
+}
+
+// WithContext 取出 Logger 中间件挂载的 *zap.Logger（携带 trace_id）。
+//
+// ctx 中没有对应 logger 时（例如未经过 Logger 中间件），回退到 zap.L() 全局 logger。
+func WithContext(ctx context.Context) *zap.Logger {
+	if ctx == nil {
+		return zap.L()
+	}
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok && l != nil {
+		return l
+	}
+	return zap.L()
+}
+
+// extractTraceId 从请求头中提取 trace_id：优先 X-Request-Id，其次 traceparent，都没有则随机生成。
+func extractTraceId(r *http.Request) string {
+	if id := strings.TrimSpace(r.Header.Get(HeaderRequestId)); id != "" {
+		return id
+	}
+	// traceparent 格式：version-traceid-parentid-flags，取其中的 traceid 段。
+	if tp := strings.TrimSpace(r.Header.Get(HeaderTraceParent)); tp != "" {
+		parts := strings.Split(tp, "-")
+		if len(parts) >= 2 && parts[1] != "" {
+			return parts[1]
+		}
+	}
+	return newTraceId()
+}
+
+// newTraceId 生成一个 16 字节随机 trace_id（32 位十六进制字符串）。
+func newTraceId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// 极罕见情况下 crypto/rand 失败：退化为基于时间的 id，保证不中断请求。
+		return hex.EncodeToString([]byte(time.Now().Format("20060102150405.000000000")))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,89 @@
+package ginlog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestLoggerPropagatesIncomingTraceId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var traceIdInHandler string
+	r := gin.New()
+	r.Use(Logger(zap.NewNop()))
+	r.GET("/ping", func(c *gin.Context) {
+		traceIdInHandler = c.GetString(FieldTraceId)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(HeaderRequestId, "incoming-trace-id")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if traceIdInHandler != "incoming-trace-id" {
+		t.Fatalf("expected handler to see propagated trace id, got %q", traceIdInHandler)
+	}
+	if got := rec.Header().Get(HeaderRequestId); got != "incoming-trace-id" {
+		t.Fatalf("expected response header to echo trace id, got %q", got)
+	}
+}
+
+func TestLoggerGeneratesTraceIdWhenAbsent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Logger(zap.NewNop()))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Header().Get(HeaderRequestId) == "" {
+		t.Fatalf("expected a generated trace id to be set on the response")
+	}
+}
+
+func TestLoggerFallsBackToTraceParentHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Logger(zap.NewNop()))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(HeaderRequestId); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected trace id parsed from traceparent, got %q", got)
+	}
+}
+
+func TestRecoveryRecoversPanicAndReturns500(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Recovery(zap.NewNop(), false))
+	r.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500 after recovering panic, got %d", rec.Code)
+	}
+}
+
+func TestWithContextFallsBackToGlobalLogger(t *testing.T) {
+	if l := WithContext(nil); l != zap.L() {
+		t.Fatalf("expected WithContext(nil) to fall back to zap.L()")
+	}
+}
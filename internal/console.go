@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewConsoleCore 构造一个面向人读的控制台 core，输出到 stdout。
+//
+// 使用 ConsoleEncoder 而非 JSON：日志等级/时间等字段以易读的形式排列，并带颜色，
+// 适合本地开发与运维盯屏；机器解析场景见 NewRemoteCore/NewFileCore。
+func NewConsoleCore(level zapcore.LevelEnabler) zapcore.Core {
+	encoderConfig := zap.NewDevelopmentEncoderConfig()
+	encoderConfig.EncodeTime = func(t time.Time, encoder zapcore.PrimitiveArrayEncoder) {
+		encoder.AppendString(t.Format(time.DateTime))
+	}
+	encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+
+	enc := zapcore.NewConsoleEncoder(encoderConfig)
+	return zapcore.NewCore(enc, zapcore.AddSync(os.Stdout), level)
+}
@@ -0,0 +1,10 @@
+package internal
+
+import "go.uber.org/zap/zapcore"
+
+// FieldExtractor 从一条日志的 entry/fields 中提取额外字段，由 remoteCore/remoteSinkCore
+// 在 Write 时一并写入下游 JSON 的 Extra 字段，用于扩展标准 trace_id 之外的上下文信息
+// （例如 span_id、user_id、tenant_id，或业务自定义的维度）。
+type FieldExtractor interface {
+	Extract(entry zapcore.Entry, fields []zapcore.Field) map[string]any
+}
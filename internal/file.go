@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileCoreConfig 描述落盘文件的轮转策略，对应公开的 logger.FileConf 中除 ErrorPath 以外的字段
+// （ErrorPath 由 New 另建一个 core 处理）。
+type FileCoreConfig struct {
+	// Path 是日志文件路径（含文件名）。
+	Path string
+	// MaxSize 是单个日志文件的最大体积（MB），超过后触发切割。
+	MaxSize int
+	// MaxBackups 是保留的历史文件个数，超出部分会被清理。
+	MaxBackups int
+	// MaxAge 是历史文件的最大保留天数。
+	MaxAge int
+	// Compress 决定历史文件是否用 gzip 压缩。
+	Compress bool
+	// LocalTime 决定备份文件名中的时间戳是否使用本地时区（默认为 UTC）。
+	LocalTime bool
+}
+
+// NewFileCore 构造一个写入本地文件的 core，底层由 lumberjack 负责按大小/时间轮转。
+//
+// level 由调用方传入（通常是 New 中共享的 atomicLevel），enabler 可选：
+// 传入非 nil 时用于在共享 level 之上进一步限制本 core 输出的等级（例如单独拆分 error.log）。
+func NewFileCore(level zapcore.LevelEnabler, cfg FileCoreConfig, enabler zapcore.LevelEnabler) zapcore.Core {
+	// 使用 ProductionEncoderConfig 的 JSON 编码，字段名为 message/path/created_at；
+	// 不与 console（DevelopmentEncoderConfig + 彩色等级）共用编码配置，字段名也与 remote 的 Path/Content/CreatedAt 不同。
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = func(t time.Time, encoder zapcore.PrimitiveArrayEncoder) {
+		encoder.AppendString(t.Format(time.DateTime))
+	}
+	encoderConfig.MessageKey = "message"
+	encoderConfig.CallerKey = "path"
+	encoderConfig.TimeKey = "created_at"
+
+	enc := zapcore.NewJSONEncoder(encoderConfig)
+
+	// lumberjack.Logger 本身就是 io.Writer，AddSync 包一层即可当作 zapcore.WriteSyncer 使用。
+	writeSync := zapcore.AddSync(&lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSize,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAge,
+		Compress:   cfg.Compress,
+		LocalTime:  cfg.LocalTime,
+	})
+
+	// 未单独指定 enabler 时直接使用共享 level，行为与 console/remote 一致。
+	if enabler == nil {
+		enabler = level
+	}
+
+	return zapcore.NewCore(enc, writeSync, enabler)
+}
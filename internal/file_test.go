@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewFileCoreRotatesOnMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	core := NewFileCore(zapcore.DebugLevel, FileCoreConfig{
+		Path:       path,
+		MaxSize:    1, // MB；写入量超过这个值即可在测试里触发轮转
+		MaxBackups: 2,
+	}, nil)
+	logger := zap.New(core)
+
+	line := strings.Repeat("a", 1024)
+	for i := 0; i < 1100; i++ {
+		logger.Info(line)
+	}
+	_ = logger.Sync()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) < 2 {
+		t.Fatalf("expected rotation to produce at least one backup file, got %d entries: %v", len(entries), entries)
+	}
+}
+
+func TestNewFileCoreWithEnablerSplitsErrorLevel(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "app.log")
+	errPath := filepath.Join(dir, "error.log")
+
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	mainCore := NewFileCore(level, FileCoreConfig{Path: mainPath}, nil)
+	errEnabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return level.Enabled(l) && l >= zapcore.ErrorLevel
+	})
+	errCore := NewFileCore(level, FileCoreConfig{Path: errPath}, errEnabler)
+
+	logger := zap.New(zapcore.NewTee(mainCore, errCore))
+	logger.Info("info line")
+	logger.Error("error line")
+	_ = logger.Sync()
+
+	mainContent, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("failed to read main log: %v", err)
+	}
+	errContent, err := os.ReadFile(errPath)
+	if err != nil {
+		t.Fatalf("failed to read error log: %v", err)
+	}
+
+	if !strings.Contains(string(mainContent), "info line") || !strings.Contains(string(mainContent), "error line") {
+		t.Fatalf("expected main log to contain both lines, got: %s", mainContent)
+	}
+	if strings.Contains(string(errContent), "info line") {
+		t.Fatalf("expected error log to exclude info line, got: %s", errContent)
+	}
+	if !strings.Contains(string(errContent), "error line") {
+		t.Fatalf("expected error log to contain error line, got: %s", errContent)
+	}
+}
@@ -18,6 +18,8 @@ type remoteLog struct {
 	TraceId string `json:"TraceId"`
 	// CreatedAt 是日志时间，使用可读的 time.DateTime 格式。
 	CreatedAt string `json:"CreatedAt"`
+	// Extra 可选字段：设置了 FieldExtractor 时，存放其提取出的附加字段。
+	Extra map[string]any `json:"Extra,omitempty"`
 }
 
 type remoteCore struct {
@@ -25,18 +27,23 @@ type remoteCore struct {
 	level zapcore.LevelEnabler
 	// handle 是远端写入回调：接收 JSON bytes。
 	handle func(b []byte)
-	// fields 为通过 Logger.With(...) 挂载的“常驻字段”。
-	fields []zapcore.Field
+	// extractor 可选：用于提取 trace_id 之外的附加字段。
+	extractor FieldExtractor
+
+	// trace 缓存 Logger.With(...) 挂载的字段及其中的 trace_id，
+	// Write 热路径优先复用它，避免每条日志都重新扫描挂载字段；extractor 的结果不缓存。
+	trace traceCache
 }
 
 // NewRemoteCore 构造一个远端输出 core。
 //
 // 该 core 的目标是减少额外编解码：直接在 core.Write 中组装目标 JSON，并调用 handle。
-func NewRemoteCore(level zapcore.LevelEnabler, handle func(b []byte)) zapcore.Core {
+// extractor 可选，用于提取 trace_id 之外的附加字段（为 nil 时只提取 trace_id）。
+func NewRemoteCore(level zapcore.LevelEnabler, handle func(b []byte), extractor FieldExtractor) zapcore.Core {
 	return &remoteCore{
-		level:  level,
-		handle: handle,
-		fields: nil,
+		level:     level,
+		handle:    handle,
+		extractor: extractor,
 	}
 }
 
@@ -50,9 +57,9 @@ func (c *remoteCore) With(fields []zapcore.Field) zapcore.Core {
 	if len(fields) == 0 {
 		return c
 	}
-	// 值拷贝保留旧 core 的配置，再复制并追加字段，避免修改原切片带来的数据竞争。
+	// 值拷贝保留旧 core 的配置，再叠加新字段，避免修改原 core 带来的数据竞争。
 	next := *c
-	next.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+	next.trace = c.trace.withFields(fields)
 	return &next
 }
 
@@ -70,20 +77,9 @@ func (c *remoteCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 		return nil
 	}
 
-	// allFields 合并 With(...) 挂载的字段与本次日志携带字段，便于统一提取 trace_id。
-	allFields := fields
-	if len(c.fields) != 0 {
-		allFields = append(append([]zapcore.Field(nil), c.fields...), fields...)
-	}
-
-	// traceId 从 fields 中提取，优先匹配标准 snake_case（trace_id），兼容历史的 TraceId。
-	traceId := ""
-	for _, f := range allFields {
-		if (f.Key == "trace_id" || f.Key == "TraceId") && f.Type == zapcore.StringType {
-			traceId = f.String
-			break
-		}
-	}
+	// 常见情况下本次调用不携带额外字段（trace_id 等已经在 With(...) 时挂载并缓存），
+	// 直接复用缓存值，避免每条日志都重新扫描/拼接字段切片。
+	traceId, extra := c.trace.resolve(entry, fields, c.extractor)
 
 	// path 优先使用 zap 提供的 Caller（需要上层 zap.AddCaller()）。
 	path := ""
@@ -98,6 +94,7 @@ func (c *remoteCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 		Content:   entry.Message,
 		TraceId:   traceId,
 		CreatedAt: entry.Time.Format(time.DateTime),
+		Extra:     extra,
 	})
 	// JSON 序列化失败时丢弃该条日志（不返回错误，保持日志不影响业务）。
 	if err == nil {
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/fireflycore/go-logger/internal/sinks"
+	"go.uber.org/zap/zapcore"
+)
+
+// remoteSinkCore 与 remoteCore 的区别在于输出目的地是一个 sinks.Sink，
+// 批量、重试、背压由具体实现（HTTP 批量/Kafka/gRPC）负责，而不是一个简单回调。
+type remoteSinkCore struct {
+	// level 控制该 core 允许输出的最小日志等级。
+	level zapcore.LevelEnabler
+	// sink 是远端写入目的地。
+	sink sinks.Sink
+	// extractor 可选：用于提取 trace_id 之外的附加字段。
+	extractor FieldExtractor
+
+	// trace 缓存 Logger.With(...) 挂载的字段及其中的 trace_id，
+	// Write 热路径优先复用它，避免每条日志都重新扫描挂载字段；extractor 的结果不缓存。
+	trace traceCache
+}
+
+// NewRemoteSinkCore 构造一个基于 RemoteSink 的远端输出 core。
+// extractor 可选，用于提取 trace_id 之外的附加字段（为 nil 时只提取 trace_id）。
+func NewRemoteSinkCore(level zapcore.LevelEnabler, sink sinks.Sink, extractor FieldExtractor) zapcore.Core {
+	return &remoteSinkCore{level: level, sink: sink, extractor: extractor}
+}
+
+func (c *remoteSinkCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *remoteSinkCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+	next := *c
+	next.trace = c.trace.withFields(fields)
+	return &next
+}
+
+func (c *remoteSinkCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *remoteSinkCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	// sink 未设置时直接忽略写入，避免影响业务。
+	if c.sink == nil {
+		return nil
+	}
+
+	// 常见情况下本次调用不携带额外字段，直接复用 With(...) 时缓存的值。
+	traceId, extra := c.trace.resolve(entry, fields, c.extractor)
+
+	path := ""
+	if entry.Caller.Defined {
+		path = entry.Caller.TrimmedPath()
+	}
+
+	b, err := json.Marshal(&remoteLog{
+		Path:      path,
+		Level:     levelToInt(entry.Level),
+		Content:   entry.Message,
+		TraceId:   traceId,
+		CreatedAt: entry.Time.Format(time.DateTime),
+		Extra:     extra,
+	})
+	// JSON 序列化失败时丢弃该条日志（不返回错误，保持日志不影响业务）。
+	if err != nil {
+		return nil
+	}
+
+	// Sink.Write 可能是网络 IO，失败同样吞掉：日志管道不应反过来影响业务逻辑。
+	// traceId 直接传给 sink（例如 KafkaSink 用作分区 key），避免 sink 反序列化 b 重新提取一遍。
+	_ = c.sink.Write(context.Background(), traceId, b)
+	return nil
+}
+
+func (c *remoteSinkCore) Sync() error {
+	if c.sink == nil {
+		return nil
+	}
+	return c.sink.Flush(context.Background())
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// traceFieldExtractor 是一个测试用的 FieldExtractor：提取 user_id，同时把
+// entry.Message 也写进结果，用于验证 extra 确实是用每条日志的真实 entry 算出来的，
+// 而不是 With(...) 时用零值 Entry 预算一次然后永远复用。
+type traceFieldExtractor struct{}
+
+func (traceFieldExtractor) Extract(entry zapcore.Entry, fields []zapcore.Field) map[string]any {
+	out := map[string]any{"message": entry.Message}
+	for _, f := range fields {
+		if f.Key == "user_id" && f.Type == zapcore.StringType {
+			out["user_id"] = f.String
+		}
+	}
+	return out
+}
+
+// TestRemoteCoreWithThenWriteReusesCache 验证 With(trace_id/user_id) 之后，
+// 不带 per-call 字段的 Write 会直接复用缓存的 trace_id，而带 per-call trace_id 的 Write
+// 会用它覆盖缓存值——这正是 remoteCore/remoteSinkCore 共用的 traceCache 热路径。
+// 同时验证 Extra 始终用当条日志的真实 entry 计算，即便 trace_id 走的是缓存。
+func TestRemoteCoreWithThenWriteReusesCache(t *testing.T) {
+	var got []byte
+	handle := func(b []byte) { got = b }
+
+	core := NewRemoteCore(zapcore.DebugLevel, handle, traceFieldExtractor{})
+	core = core.With([]zapcore.Field{
+		{Key: "trace_id", Type: zapcore.StringType, String: "trace-1"},
+		{Key: "user_id", Type: zapcore.StringType, String: "u-1"},
+	})
+
+	if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "first"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(got, &m); err != nil {
+		t.Fatalf("invalid json output: %v", err)
+	}
+	if m["TraceId"] != "trace-1" {
+		t.Fatalf("expected cached TraceId trace-1, got %v", m["TraceId"])
+	}
+	extra, _ := m["Extra"].(map[string]any)
+	if extra["user_id"] != "u-1" {
+		t.Fatalf("expected cached Extra.user_id u-1, got %v", extra)
+	}
+	if extra["message"] != "first" {
+		t.Fatalf("expected Extra to reflect the first entry's message, got %v", extra)
+	}
+
+	// 第二条日志换了 message 和 per-call trace_id：trace_id 应该被覆盖，Extra 里的
+	// message 也必须随真实 entry 更新——如果 extra 是在 With(...) 时用零值 Entry
+	// 预先算好并一直复用的，这里会仍然看到 "first"。
+	if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "second"}, []zapcore.Field{
+		{Key: "trace_id", Type: zapcore.StringType, String: "trace-2"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(got, &m); err != nil {
+		t.Fatalf("invalid json output: %v", err)
+	}
+	if m["TraceId"] != "trace-2" {
+		t.Fatalf("expected per-call TraceId to override cache, got %v", m["TraceId"])
+	}
+	extra, _ = m["Extra"].(map[string]any)
+	if extra["user_id"] != "u-1" {
+		t.Fatalf("expected Extra to still include With-mounted user_id, got %v", extra)
+	}
+	if extra["message"] != "second" {
+		t.Fatalf("expected Extra to reflect the second entry's message, got %v", extra)
+	}
+}
+
+// TestRemoteCoreWriteRecomputesExtraWithRealEntryOnFastPath 覆盖最常见的情况：
+// With(...) 挂载 trace_id 后连续写多条日志、且都不带 per-call 字段（即走 traceId
+// 缓存命中的 fast path）。即便 fast path 只复用缓存的 traceId，Extra 也必须按每条
+// 日志各自的 entry 重新计算，不能固定成 With(...) 时算出来的那一份。
+func TestRemoteCoreWriteRecomputesExtraWithRealEntryOnFastPath(t *testing.T) {
+	var got []byte
+	handle := func(b []byte) { got = b }
+
+	core := NewRemoteCore(zapcore.DebugLevel, handle, traceFieldExtractor{})
+	core = core.With([]zapcore.Field{
+		{Key: "trace_id", Type: zapcore.StringType, String: "trace-1"},
+	})
+
+	messages := []string{"m1", "m2"}
+	for _, msg := range messages {
+		if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: msg}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var m map[string]any
+		if err := json.Unmarshal(got, &m); err != nil {
+			t.Fatalf("invalid json output: %v", err)
+		}
+		extra, _ := m["Extra"].(map[string]any)
+		if extra["message"] != msg {
+			t.Fatalf("expected Extra.message to track the real entry (%q), got %v", msg, extra)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package sinks
+
+import "context"
+
+// LogStreamer 是一个最小的流式写入接口：发送原始字节、半关闭发送方向。
+// GRPCSink 本身不生成/依赖任何 gRPC 代码，stream 由调用方传入，通常由某个
+// protoc 生成的 gRPC 客户端流实现（例如把 b 作为某个 LogEntry 消息的序列化结果
+// 发送），但这不是 sinks 包关心的事：它只负责把已经编码好的日志字节转发给 stream。
+type LogStreamer interface {
+	Send(b []byte) error
+	CloseSend() error
+}
+
+// GRPCSink 把日志通过一个调用方提供的长连接流发送到远端。
+type GRPCSink struct {
+	stream LogStreamer
+}
+
+// NewGRPCSink 创建一个 GRPCSink，stream 通常来自调用方自己的 gRPC 客户端流（由其
+// Stream(ctx) 之类的方法返回），只要实现了 LogStreamer 即可。
+func NewGRPCSink(stream LogStreamer) *GRPCSink {
+	return &GRPCSink{stream: stream}
+}
+
+// Write 把一条日志发送到 gRPC 流。key（trace_id）不参与流式发送，这里忽略即可。
+func (s *GRPCSink) Write(ctx context.Context, key string, b []byte) error {
+	return s.stream.Send(b)
+}
+
+// Flush 无需额外动作：gRPC 流式发送没有本地缓冲，这里保持接口一致。
+func (s *GRPCSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Close 半关闭发送方向，通知对端数据已发送完毕。
+func (s *GRPCSink) Close() error {
+	return s.stream.CloseSend()
+}
@@ -0,0 +1,61 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeLogStreamer 是一个内存里的 LogStreamer 实现，用于在没有真实 gRPC 连接的
+// 情况下测试 GRPCSink。
+type fakeLogStreamer struct {
+	sent    [][]byte
+	closed  bool
+	sendErr error
+}
+
+func (f *fakeLogStreamer) Send(b []byte) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, append([]byte(nil), b...))
+	return nil
+}
+
+func (f *fakeLogStreamer) CloseSend() error {
+	f.closed = true
+	return nil
+}
+
+func TestGRPCSinkWriteForwardsToStream(t *testing.T) {
+	stream := &fakeLogStreamer{}
+	sink := NewGRPCSink(stream)
+
+	if err := sink.Write(context.Background(), "trace-1", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stream.sent) != 1 || string(stream.sent[0]) != `{"a":1}` {
+		t.Fatalf("expected payload forwarded to stream, got %v", stream.sent)
+	}
+}
+
+func TestGRPCSinkWritePropagatesStreamError(t *testing.T) {
+	stream := &fakeLogStreamer{sendErr: errors.New("boom")}
+	sink := NewGRPCSink(stream)
+
+	if err := sink.Write(context.Background(), "", []byte(`{}`)); err == nil {
+		t.Fatalf("expected error from stream.Send to propagate")
+	}
+}
+
+func TestGRPCSinkCloseClosesSendDirection(t *testing.T) {
+	stream := &fakeLogStreamer{}
+	sink := NewGRPCSink(stream)
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stream.closed {
+		t.Fatalf("expected Close to call CloseSend on the underlying stream")
+	}
+}
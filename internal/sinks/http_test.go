@@ -0,0 +1,118 @@
+package sinks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPBatchSinkFlushesOnMaxBatchSize(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPBatchSink(HTTPBatchSinkConfig{
+		URL:           srv.URL,
+		MaxBatchSize:  2,
+		FlushInterval: time.Hour, // 足够长，确保本用例只由 MaxBatchSize 触发
+	})
+	defer sink.Close()
+
+	ctx := context.Background()
+	if err := sink.Write(ctx, "", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	n := requests
+	mu.Unlock()
+	if n != 0 {
+		t.Fatalf("expected no flush before reaching MaxBatchSize, got %d requests", n)
+	}
+
+	if err := sink.Write(ctx, "", []byte(`{"a":2}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	n = requests
+	mu.Unlock()
+	if n != 1 {
+		t.Fatalf("expected exactly 1 flush after reaching MaxBatchSize, got %d", n)
+	}
+}
+
+// TestHTTPBatchSinkFlushTimerRearmsOnEmptyBuffer 覆盖 Flush 在缓冲为空时仍需要
+// 重新上弦定时器：否则某个 FlushInterval 窗口恰好没有数据时，后续写入将永远等不到
+// 下一次基于时间的 flush。
+func TestHTTPBatchSinkFlushTimerRearmsOnEmptyBuffer(t *testing.T) {
+	var mu sync.Mutex
+	count := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPBatchSink(HTTPBatchSinkConfig{
+		URL:           srv.URL,
+		MaxBatchSize:  100,
+		FlushInterval: 20 * time.Millisecond,
+	})
+	defer sink.Close()
+
+	// 第一个 FlushInterval 窗口内缓冲为空，让定时器先空转一次。
+	time.Sleep(60 * time.Millisecond)
+
+	if err := sink.Write(context.Background(), "", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := count
+		mu.Unlock()
+		if n >= 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected flush timer to still fire after an empty-buffer tick")
+}
+
+func TestHTTPBatchSinkWriteAfterCloseReturnsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPBatchSink(HTTPBatchSinkConfig{URL: srv.URL})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error closing sink: %v", err)
+	}
+
+	if err := sink.Write(context.Background(), "", []byte(`{}`)); err == nil {
+		t.Fatalf("expected write after close to return an error")
+	}
+}
+
+// TestNewHTTPBatchSinkDefaultsMaxRetries 验证未设置 MaxRetries 时（零值，最常见的
+// "字段留空" 情况）套用文档里写的默认值 3，而不是被当成显式的 0 次重试。
+func TestNewHTTPBatchSinkDefaultsMaxRetries(t *testing.T) {
+	sink := NewHTTPBatchSink(HTTPBatchSinkConfig{URL: "http://example.invalid"})
+	defer sink.Close()
+
+	if sink.cfg.MaxRetries != 3 {
+		t.Fatalf("expected default MaxRetries to be 3, got %d", sink.cfg.MaxRetries)
+	}
+}
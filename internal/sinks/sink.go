@@ -0,0 +1,19 @@
+// Package sinks 提供 RemoteSink 的内置实现：HTTP 批量上报、Kafka、gRPC 流式写入。
+//
+// 在引入本包之前，Remote 输出只能依赖调用方自己实现的 handle func([]byte)，
+// 批量、重试、背压都要各自重复实现一遍。Sink 把这些策略收敛到具体实现内部。
+package sinks
+
+import "context"
+
+// Sink 是远端日志写入的统一接口。
+type Sink interface {
+	// Write 写入一条日志的原始字节（JSON），实现可自行决定是否缓冲/批量发送。
+	// key 是调用方（remoteSinkCore）已经提取好的 trace_id，供需要按调用链
+	// 分区/分片的实现（例如 KafkaSink）直接使用，避免反序列化 b 重新取一遍。
+	Write(ctx context.Context, key string, b []byte) error
+	// Flush 把缓冲中尚未发送的数据立即发送出去。
+	Flush(ctx context.Context) error
+	// Close 释放底层资源（连接、后台 goroutine 等），调用后不应再 Write。
+	Close() error
+}
@@ -0,0 +1,53 @@
+package internal
+
+import "go.uber.org/zap/zapcore"
+
+// traceCache 缓存 Logger.With(...) 挂载字段中已经确定的 trace_id，被 remoteCore 与
+// remoteSinkCore 共用：两者都希望 Write 热路径在没有 per-call 字段时直接复用
+// With(...) 时预先算好的 trace_id，不必每条日志都重新扫描挂载字段。
+//
+// extra 不缓存：FieldExtractor.Extract 按接口约定接收真实的 entry，如果在 With(...)
+// 时用零值 Entry 预算一次并长期复用，任何读取 entry.Level/Message/Time 的 extractor
+// 实现都会在每条日志上拿到错误数据，所以 extra 固定在 resolve 里用调用时的真实 entry 计算。
+type traceCache struct {
+	// fields 为通过 Logger.With(...) 挂载的“常驻字段”。
+	fields []zapcore.Field
+	// traceId 是预先从 fields 提取的 trace_id。
+	traceId string
+}
+
+// withFields 返回挂载了新 fields 后的 traceCache，预先索引 trace_id。
+// fields 为空时返回 c 本身，调用方应沿用原 core（保持 zapcore.Core.With 的惯例）。
+func (c traceCache) withFields(fields []zapcore.Field) traceCache {
+	next := c
+	next.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+
+	for _, f := range fields {
+		if (f.Key == "trace_id" || f.Key == "TraceId") && f.Type == zapcore.StringType {
+			next.traceId = f.String
+		}
+	}
+	return next
+}
+
+// resolve 返回某条日志实际应使用的 traceId/extra。traceId 在没有 per-call 字段时
+// 直接复用缓存值；extra 设置了 extractor 时，总是用真实的 entry 与（缓存字段 +
+// per-call 字段）重新计算，避免上面提到的零值 entry 问题。
+func (c traceCache) resolve(entry zapcore.Entry, callFields []zapcore.Field, extractor FieldExtractor) (traceId string, extra map[string]any) {
+	traceId = c.traceId
+	fields := c.fields
+
+	if len(callFields) != 0 {
+		for _, f := range callFields {
+			if (f.Key == "trace_id" || f.Key == "TraceId") && f.Type == zapcore.StringType {
+				traceId = f.String
+			}
+		}
+		fields = append(append([]zapcore.Field(nil), c.fields...), callFields...)
+	}
+
+	if extractor != nil {
+		extra = extractor.Extract(entry, fields)
+	}
+	return traceId, extra
+}
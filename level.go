@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ServeLevelHTTP 暴露一个可动态调整日志等级的 HTTP handler，兼容 zap 的约定：
+// GET 返回 {"level":"info"}；PUT 请求体为同样的 JSON，用于设置新等级。
+//
+// zap.AtomicLevel 本身已实现该约定（见 zap.AtomicLevel.ServeHTTP），这里只是包一层
+// 更贴合本库命名习惯的函数，便于调用方直接注册到自己的 mux 上，例如：
+// mux.HandleFunc("/loglevel", logger.ServeLevelHTTP(level))
+func ServeLevelHTTP(level zap.AtomicLevel) http.HandlerFunc {
+	return level.ServeHTTP
+}
+
+// WatchSIGHUP 安装一个 SIGHUP 信号处理器：收到信号时从 path 读取新等级并写入 level。
+//
+// path 内容应为 zapcore 可解析的等级字符串（debug/info/warn/error/dpanic/panic/fatal）；
+// 读取或解析失败时忽略本次信号，保持原等级不变，避免误配置打断日志输出。
+// 返回的 stop 用于取消监听、释放信号通道，不再需要动态调整时应调用。
+func WatchSIGHUP(level zap.AtomicLevel, path string) (stop func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sig:
+				reloadLevelFromFile(level, path)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
+
+// reloadLevelFromFile 从 path 读取等级字符串并写入 level；失败时静默忽略。
+func reloadLevelFromFile(level zap.AtomicLevel, path string) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	parsed, err := zapcore.ParseLevel(strings.TrimSpace(string(b)))
+	if err != nil {
+		return
+	}
+	level.SetLevel(parsed)
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestServeLevelHTTP(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	handler := ServeLevelHTTP(level)
+
+	// GET 应返回当前等级。
+	req := httptest.NewRequest(http.MethodGet, "/loglevel", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if !strings.Contains(rec.Body.String(), `"level":"info"`) {
+		t.Fatalf("expected level info in response, got: %s", rec.Body.String())
+	}
+
+	// PUT 应更新等级。
+	req = httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`))
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if level.Level() != zap.DebugLevel {
+		t.Fatalf("expected level to be updated to debug, got: %v", level.Level())
+	}
+}
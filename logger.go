@@ -1,25 +1,86 @@
 package logger
 
 import (
-	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/fireflycore/go-logger/internal"
+	"github.com/fireflycore/go-logger/internal/sinks"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// RemoteSink 是远端输出的写入接口，取代简单的 handle 回调：批量、重试、背压
+// 收敛在具体实现里。内置实现见 internal/sinks 包（HTTP 批量、Kafka、gRPC）。
+type RemoteSink = sinks.Sink
+
+// FieldExtractor 从一条日志的 entry/fields 中提取额外字段（map[string]any），
+// 由 remoteCore/remoteSinkCore 在 Write 时一并写入下游 JSON 的 Extra 字段，
+// 用于扩展标准 trace_id 之外的上下文信息（例如 span_id、user_id、tenant_id）。
+// 搭配 ContextFields 使用：logger.With(logger.ContextFields(ctx)...)。
+type FieldExtractor = internal.FieldExtractor
+
 // Conf 是 logger 的配置项。
 // - Console：是否启用控制台输出
-// - Remote：是否启用远端输出（需要同时提供 handle 才会生效）
+// - Remote：是否启用远端输出（需要同时提供 handle 或 RemoteSink 才会生效）
+// - File：是否启用落盘输出（需要同时提供 FileConf 才会生效）
+// - Sampling：是否启用采样（为 nil 时不采样，全量输出）
 type Conf struct {
-	Console bool   `json:"console"`
-	Remote  bool   `json:"remote"`
-	Level   string `json:"level"`
+	Console  bool      `json:"console"`
+	Remote   bool      `json:"remote"`
+	File     bool      `json:"file"`
+	Level    string    `json:"level"`
+	FileConf *FileConf `json:"file_conf,omitempty"`
+	Sampling *Sampling `json:"sampling,omitempty"`
+
+	// SamplingHook 可选：每条日志被采样决策后触发（Sampled/Dropped），用于统计丢弃量。
+	// 仅在 Sampling 非 nil 时生效。
+	SamplingHook func(entry zapcore.Entry, dec zapcore.SamplingDecision)
+
+	// RemoteSink 可选：与 handle（由 New 的第二个参数传入）互斥，优先生效。
+	// 设置后 Remote 输出直接写入该 sink，由其自身负责批量/重试/背压。
+	RemoteSink RemoteSink
+
+	// Extractor 可选：为 nil 时 Remote/RemoteSink 只提取 trace_id；设置后额外提取的
+	// 字段会写入输出 JSON 的 Extra。
+	Extractor FieldExtractor
 
 	handle func(b []byte)
 }
 
+// Sampling 描述按 (level, message) 维度的限流策略，对应 zapcore.NewSamplerWithOptions。
+//
+// 每个 Tick 窗口内，同一 (level, message) 组合的前 Initial 条全部放行；
+// 超过之后每 Thereafter 条放行 1 条，其余丢弃。
+type Sampling struct {
+	// Initial 是每个 Tick 窗口内无条件放行的条数。
+	Initial int `json:"initial"`
+	// Thereafter 是超过 Initial 后的放行间隔，例如 100 表示每 100 条放行 1 条。
+	Thereafter int `json:"thereafter"`
+	// Tick 是采样窗口长度，零值时由 New 回退为 1 秒。
+	Tick time.Duration `json:"tick"`
+}
+
+// FileConf 描述 File 输出的落盘与轮转策略，底层由 lumberjack 实现。
+type FileConf struct {
+	// Path 是日志文件路径（含文件名），例如 "./logs/app.log"。
+	Path string `json:"path"`
+	// MaxSize 是单个日志文件的最大体积（MB），超过后触发切割，默认由 lumberjack 决定（100MB）。
+	MaxSize int `json:"max_size"`
+	// MaxBackups 是保留的历史文件个数，0 表示不限制。
+	MaxBackups int `json:"max_backups"`
+	// MaxAge 是历史文件的最大保留天数，0 表示不按时间清理。
+	MaxAge int `json:"max_age"`
+	// Compress 决定历史文件是否用 gzip 压缩。
+	Compress bool `json:"compress"`
+	// LocalTime 决定备份文件名中的时间戳是否使用本地时区（默认为 UTC）。
+	LocalTime bool `json:"local_time"`
+
+	// ErrorPath 可选：单独指定一份 Error 及以上等级的日志文件路径（例如 error.log）。
+	// 为空时所有等级共用 Path。
+	ErrorPath string `json:"error_path,omitempty"`
+}
+
 // WithHandle 设置远端输出回调。
 //
 // handle 会接收到经过本库二次整理的 JSON 字节串（outputLog 格式）。
@@ -27,7 +88,10 @@ func (c *Conf) WithHandle(handle func(b []byte)) {
 	c.handle = handle
 }
 
-// Write 实现 io.Writer，用于把 zap 的 JSON 输出重定向到 handle。
+// Write 实现 io.Writer，用于把日志字节转发给 handle。
+//
+// 搭配 NewOutputEncoder 使用时，b 已经是 outputLog 字段格式的 JSON，无需再解析/重组
+// （历史版本这里会 Unmarshal 成 zapLog 再 Marshal 成 outputLog，现在由 encoder 直接产出该格式）。
 //
 // 这里不返回错误：日志写入失败不应影响业务逻辑（保持可用性优先）。
 func (c *Conf) Write(b []byte) (int, error) {
@@ -36,30 +100,7 @@ func (c *Conf) Write(b []byte) (int, error) {
 		return len(b), nil
 	}
 
-	// 解析 zap JSON 输出，提取必要字段并做字段名/等级转换。
-	var data zapLog
-	if err := json.Unmarshal(b, &data); err != nil {
-		// 如果解析失败，直接透传原始字节，尽可能不丢日志。
-		c.handle(b)
-		return len(b), nil
-	}
-
-	// 组装为兼容下游的输出结构。
-	out, err := json.Marshal(&outputLog{
-		Path:      data.Path,
-		Level:     levelToInt(data.Level),
-		Content:   data.Message,
-		TraceId:   data.TraceId,
-		CreatedAt: data.CreatedAt,
-	})
-	if err != nil {
-		// 序列化失败同样透传原始字节，避免完全丢失。
-		c.handle(b)
-		return len(b), nil
-	}
-
-	// 将结构化日志交给调用方处理（例如写入远端）。
-	c.handle(out)
+	c.handle(b)
 
 	return len(b), nil
 }
@@ -67,15 +108,19 @@ func (c *Conf) Write(b []byte) (int, error) {
 // New 构造一个 zap.Logger。
 //
 // - Console=true 时输出到 stdout（面向人读）
-// - Remote=true 且提供 handle 时输出 JSON 到 handle（面向机器解析）
-// - 两者都未启用时返回 Nop logger，避免 nil 引用
-func New(conf *Conf, handle func(b []byte)) *zap.Logger {
+// - Remote=true 且提供 handle 或 RemoteSink 时输出 JSON（面向机器解析）
+// - File=true 且提供 FileConf 时输出 JSON 到本地文件，由 lumberjack 按大小/时间轮转
+// - 均未启用时返回 Nop logger，避免 nil 引用
+//
+// 同时返回构造时使用的 zap.AtomicLevel，调用方可以用它在运行时动态调整日志等级
+// （见 ServeLevelHTTP、WatchSIGHUP），无需重建 Logger。
+func New(conf *Conf, handle func(b []byte)) (*zap.Logger, zap.AtomicLevel) {
 	// 允许传 nil：返回 nop，保持调用方简洁。
 	if conf == nil {
-		return zap.NewNop()
+		return zap.NewNop(), zap.NewAtomicLevel()
 	}
 
-	if conf.handle != nil {
+	if handle != nil {
 		conf.handle = handle
 	}
 
@@ -91,21 +136,65 @@ func New(conf *Conf, handle func(b []byte)) *zap.Logger {
 	atomicLevel := zap.NewAtomicLevelAt(level)
 
 	// 多个 core 通过 Tee 合并，保证同一条日志可同时输出到多个目的地。
-	cores := make([]zapcore.Core, 0, 2)
+	cores := make([]zapcore.Core, 0, 3)
 	if conf.Console {
 		cores = append(cores, internal.NewConsoleCore(atomicLevel))
 	}
-	// Remote 需要 conf.handle，否则无法写入，避免产生“启用但无输出”的隐式失败。
-	if conf.Remote && conf.handle != nil {
-		// Remote 走自定义 core：避免 JSON 编码后再解析/重组的额外开销。
-		cores = append(cores, internal.NewRemoteCore(atomicLevel, handle))
+	// Remote 需要 conf.RemoteSink 或 conf.handle，否则无法写入，避免产生“启用但无输出”的隐式失败。
+	if conf.Remote {
+		switch {
+		case conf.RemoteSink != nil:
+			// RemoteSink 优先：批量/重试/背压收敛在具体实现里，而不是回调自己处理。
+			cores = append(cores, internal.NewRemoteSinkCore(atomicLevel, conf.RemoteSink, conf.Extractor))
+		case conf.handle != nil:
+			// Remote 走自定义 core：避免 JSON 编码后再解析/重组的额外开销。
+			// 用 conf.handle（而不是入参 handle）：Conf.WithHandle 配置的回调也要走这条路径。
+			cores = append(cores, internal.NewRemoteCore(atomicLevel, conf.handle, conf.Extractor))
+		}
+	}
+	// File 需要 conf.FileConf，否则不知道写到哪里，避免产生“启用但无输出”的隐式失败。
+	if conf.File && conf.FileConf != nil {
+		fileCfg := internal.FileCoreConfig{
+			Path:       conf.FileConf.Path,
+			MaxSize:    conf.FileConf.MaxSize,
+			MaxBackups: conf.FileConf.MaxBackups,
+			MaxAge:     conf.FileConf.MaxAge,
+			Compress:   conf.FileConf.Compress,
+			LocalTime:  conf.FileConf.LocalTime,
+		}
+		cores = append(cores, internal.NewFileCore(atomicLevel, fileCfg, nil))
+
+		// ErrorPath 可选：额外拆出一份只含 Error 及以上等级的文件，便于运维单独盯盘。
+		if strings.TrimSpace(conf.FileConf.ErrorPath) != "" {
+			errCfg := fileCfg
+			errCfg.Path = conf.FileConf.ErrorPath
+			errEnabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+				return atomicLevel.Enabled(l) && l >= zapcore.ErrorLevel
+			})
+			cores = append(cores, internal.NewFileCore(atomicLevel, errCfg, errEnabler))
+		}
 	}
 
 	// 没有任何输出目的地时返回 nop，避免 NewTee 空参数造成不可预期行为。
 	if len(cores) == 0 {
-		return zap.NewNop()
+		return zap.NewNop(), atomicLevel
+	}
+
+	// Sampling 配置时，对每个 core 分别包一层采样，在突发高频日志场景下做可预测的限流。
+	if conf.Sampling != nil {
+		tick := conf.Sampling.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		opts := make([]zapcore.SamplerOption, 0, 1)
+		if conf.SamplingHook != nil {
+			opts = append(opts, zapcore.SamplerHook(conf.SamplingHook))
+		}
+		for i, core := range cores {
+			cores[i] = zapcore.NewSamplerWithOptions(core, tick, conf.Sampling.Initial, conf.Sampling.Thereafter, opts...)
+		}
 	}
 
 	// AddCaller 会在日志中加入 caller 信息，字段名由 internal encoder 的 CallerKey 控制。
-	return zap.New(zapcore.NewTee(cores...), zap.AddCaller())
+	return zap.New(zapcore.NewTee(cores...), zap.AddCaller()), atomicLevel
 }
@@ -3,6 +3,7 @@ package logger
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -86,9 +87,9 @@ func TestNew(t *testing.T) {
 			// 按用例决定是否提供 handle，模拟真实调用场景。
 			var logger *zap.Logger
 			if tt.withHandle {
-				logger = New(tt.config, handle)
+				logger, _ = New(tt.config, handle)
 			} else {
-				logger = New(tt.config, nil)
+				logger, _ = New(tt.config, nil)
 			}
 
 			// 写一条日志用于触发输出。
@@ -124,3 +125,56 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+// TestNewWithConfWithHandleTriggersRemoteCore 验证 Conf.WithHandle 配置的回调在
+// New(conf, nil) 调用下（即不通过 New 的第二个参数传入 handle）依然生效：
+// New 内部必须使用 conf.handle 构造 remoteCore，而不是被忽略的入参 handle。
+func TestNewWithConfWithHandleTriggersRemoteCore(t *testing.T) {
+	handleCalled := 0
+	var last []byte
+	conf := &Conf{Remote: true}
+	conf.WithHandle(func(b []byte) {
+		handleCalled++
+		last = append(last[:0], b...)
+	})
+
+	logger, _ := New(conf, nil)
+	logger.Info("测试")
+
+	if handleCalled == 0 {
+		t.Fatalf("expected Conf.WithHandle's callback to be triggered")
+	}
+	var m map[string]any
+	if err := json.Unmarshal(last, &m); err != nil {
+		t.Fatalf("expected json output, got error: %v", err)
+	}
+	if m["Content"] != "测试" {
+		t.Fatalf("expected Content to be 测试, got: %v", m["Content"])
+	}
+}
+
+// TestNewWithSamplingDropsAfterThreshold 验证 Conf.Sampling 确实包到了每个 core 上：
+// 同一 (level, message) 在一个 Tick 窗口内，超过 Initial 之后只按 Thereafter 间隔放行，
+// 其余应被丢弃。
+func TestNewWithSamplingDropsAfterThreshold(t *testing.T) {
+	handleCalled := 0
+	handle := func(b []byte) { handleCalled++ }
+
+	conf := &Conf{
+		Remote: true,
+		Sampling: &Sampling{
+			Initial:    2,
+			Thereafter: 100,
+			Tick:       time.Minute,
+		},
+	}
+	logger, _ := New(conf, handle)
+
+	for i := 0; i < 10; i++ {
+		logger.Info("重复消息")
+	}
+
+	if handleCalled != 2 {
+		t.Fatalf("expected sampling to let only Initial=2 calls through in this tick window, got %d", handleCalled)
+	}
+}